@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/redis/rueidis"
+)
+
+const (
+	tasksByTimeKey     = "tasks:by_time"
+	tasksCompletedKey  = "tasks:completed"
+	tasksEventsChannel = "tasks:events"
+	defaultTasksLimit  = 20
+)
+
+// TaskEvent - подія зміни завдання, що публікується в канал tasks:events,
+// щоб усі екземпляри застосунку могли сповістити своїх SSE-клієнтів.
+type TaskEvent struct {
+	Type string `json:"type"` // "created", "updated" або "deleted"
+	Task Task   `json:"task"`
+}
+
+func taskKey(id string) string {
+	return "task:" + id
+}
+
+// taskScore рахує score для tasks:by_time: цілу частину - created_at, а дробову -
+// детерміновану добавку з хешу FNV-1a від id. Без неї завдання, створені в ту саму
+// секунду, отримали б однаковий score, і ZREVRANGEBYSCORE з ексклюзивною межею
+// курсора відсіяла б усю групу замість одного вже повернутого завдання.
+func taskScore(id string, createdAt int64) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	frac := float64(h.Sum32()) / float64(math.MaxUint32+1)
+	return float64(createdAt) + frac
+}
+
+// ListTasksOptions керує пагінацією та фільтрацією списку завдань.
+type ListTasksOptions struct {
+	Limit     int64
+	Cursor    string // score (з taskScore) останнього завдання з попередньої сторінки; порожньо - з початку
+	Completed *bool
+}
+
+// TaskCache абстрагує Redis-шар, що зберігає завдання, щоб у тестах можна
+// було підставити фейкову реалізацію замість реального Redis.
+type TaskCache interface {
+	ListTasks(ctx context.Context, opts ListTasksOptions) (tasks []Task, nextCursor string, err error)
+	PutTask(ctx context.Context, task Task) error
+	DeleteTask(ctx context.Context, id string) error
+	PublishEvent(ctx context.Context, event TaskEvent) error
+	SubscribeEvents(ctx context.Context, onEvent func(TaskEvent)) error
+}
+
+// rueidisCache зберігає кожне завдання як хеш task:{id} (title, completed,
+// created_at), впорядковану множину tasks:by_time (score = taskScore) для
+// пагінованого лістингу та множину tasks:completed для фільтра за статусом -
+// замість одного блоба "tasks", який довелося б переписувати на кожну зміну.
+type rueidisCache struct {
+	client rueidis.Client
+}
+
+func newRueidisCache() (*rueidisCache, error) {
+	opt, err := rueidisClientOption()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := rueidis.NewClient(opt)
+	if err != nil {
+		return nil, fmt.Errorf("не вдалося створити клієнт rueidis: %w", err)
+	}
+
+	return &rueidisCache{client: client}, nil
+}
+
+func (c *rueidisCache) PutTask(ctx context.Context, task Task) error {
+	c1 := c.client.B().Hset().Key(taskKey(task.ID)).FieldValue().
+		FieldValue("title", task.Title).
+		FieldValue("completed", strconv.FormatBool(task.Completed)).
+		FieldValue("created_at", strconv.FormatInt(task.CreatedAt, 10)).
+		Build()
+	c2 := c.client.B().Zadd().Key(tasksByTimeKey).ScoreMember().
+		ScoreMember(taskScore(task.ID, task.CreatedAt), task.ID).Build()
+
+	var c3 rueidis.Completed
+	if task.Completed {
+		c3 = c.client.B().Sadd().Key(tasksCompletedKey).Member(task.ID).Build()
+	} else {
+		c3 = c.client.B().Srem().Key(tasksCompletedKey).Member(task.ID).Build()
+	}
+
+	for _, resp := range c.client.DoMulti(ctx, c1, c2, c3) {
+		if err := resp.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *rueidisCache) DeleteTask(ctx context.Context, id string) error {
+	c1 := c.client.B().Del().Key(taskKey(id)).Build()
+	c2 := c.client.B().Zrem().Key(tasksByTimeKey).Member(id).Build()
+	c3 := c.client.B().Srem().Key(tasksCompletedKey).Member(id).Build()
+
+	for _, resp := range c.client.DoMulti(ctx, c1, c2, c3) {
+		if err := resp.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PublishEvent публікує подію зміни завдання в канал tasks:events, щоб SSE-
+// обробники на всіх екземплярах застосунку могли сповістити своїх клієнтів.
+func (c *rueidisCache) PublishEvent(ctx context.Context, event TaskEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("не вдалося закодувати подію: %w", err)
+	}
+
+	cmd := c.client.B().Publish().Channel(tasksEventsChannel).Message(string(payload)).Build()
+	return c.client.Do(ctx, cmd).Error()
+}
+
+// SubscribeEvents підписується на канал tasks:events і викликає onEvent для
+// кожного повідомлення, доки ctx не буде скасовано.
+func (c *rueidisCache) SubscribeEvents(ctx context.Context, onEvent func(TaskEvent)) error {
+	dedicated, cancel := c.client.Dedicate()
+	defer cancel()
+
+	subscribeCmd := dedicated.B().Subscribe().Channel(tasksEventsChannel).Build()
+	return dedicated.Receive(ctx, subscribeCmd, func(msg rueidis.PubSubMessage) {
+		var event TaskEvent
+		if err := json.Unmarshal([]byte(msg.Message), &event); err != nil {
+			return
+		}
+		onEvent(event)
+	})
+}
+
+func (c *rueidisCache) ListTasks(ctx context.Context, opts ListTasksOptions) ([]Task, string, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultTasksLimit
+	}
+
+	maxScore := "+inf"
+	if opts.Cursor != "" {
+		maxScore = "(" + opts.Cursor // "(" виключає завдання з попередньої сторінки
+	}
+
+	zrangeCmd := c.client.B().Zrevrangebyscore().
+		Key(tasksByTimeKey).
+		Max(maxScore).
+		Min("-inf").
+		Limit(0, limit).
+		Build()
+
+	ids, err := c.client.Do(ctx, zrangeCmd).AsStrSlice()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(ids) == 0 {
+		return []Task{}, "", nil
+	}
+
+	cmds := make(rueidis.Commands, 0, len(ids)*2)
+	for _, id := range ids {
+		cmds = append(cmds, c.client.B().Hgetall().Key(taskKey(id)).Build())
+	}
+	if opts.Completed != nil {
+		// Фільтр за статусом звіряється з множиною tasks:completed, а не з полем
+		// completed хеша, щоб ця множина не лишалася непотрібним write-only станом.
+		for _, id := range ids {
+			cmds = append(cmds, c.client.B().Sismember().Key(tasksCompletedKey).Member(id).Build())
+		}
+	}
+
+	resps := c.client.DoMulti(ctx, cmds...)
+
+	taskList := make([]Task, 0, len(ids))
+	var lastScore float64
+	for i, id := range ids {
+		fields, err := resps[i].AsStrMap()
+		if err != nil {
+			return nil, "", err
+		}
+
+		completed := fields["completed"] == "true"
+		createdAt, _ := strconv.ParseInt(fields["created_at"], 10, 64)
+		lastScore = taskScore(id, createdAt) // відстежуємо курсор до фільтрації, щоб пагінація не ламалась
+
+		if opts.Completed != nil {
+			isMember, err := resps[len(ids)+i].AsBool()
+			if err != nil {
+				return nil, "", err
+			}
+			if isMember != *opts.Completed {
+				continue
+			}
+		}
+
+		taskList = append(taskList, Task{
+			ID:        id,
+			Title:     fields["title"],
+			Completed: completed,
+			CreatedAt: createdAt,
+		})
+	}
+
+	nextCursor := ""
+	if int64(len(ids)) == limit {
+		nextCursor = strconv.FormatFloat(lastScore, 'f', -1, 64)
+	}
+
+	return taskList, nextCursor, nil
+}
+
+// rueidisClientOption будує rueidis.ClientOption відповідно до REDIS_MODE
+// ("single", "sentinel" або "cluster"), так само як раніше робив клієнт
+// go-redis, щоб розгортання поверх HA-топологій Redis не змінилося.
+func rueidisClientOption() (rueidis.ClientOption, error) {
+	mode := os.Getenv("REDIS_MODE")
+	if mode == "" {
+		mode = "single"
+	}
+
+	switch mode {
+	case "single":
+		redisURL := os.Getenv("REDIS_URL")
+		if redisURL == "" {
+			return rueidis.ClientOption{InitAddress: []string{"localhost:6379"}}, nil
+		}
+		opt, err := rueidis.ParseURL(redisURL)
+		if err != nil {
+			return rueidis.ClientOption{}, fmt.Errorf("неправильний REDIS_URL: %w", err)
+		}
+		return opt, nil
+
+	case "sentinel":
+		masterName := os.Getenv("REDIS_MASTER_NAME")
+		sentinelAddrs := splitAddrs(os.Getenv("REDIS_SENTINEL_ADDRS"))
+		if masterName == "" || len(sentinelAddrs) == 0 {
+			return rueidis.ClientOption{}, fmt.Errorf("для режиму sentinel потрібні REDIS_MASTER_NAME та REDIS_SENTINEL_ADDRS")
+		}
+		return rueidis.ClientOption{
+			InitAddress: sentinelAddrs,
+			Sentinel:    rueidis.SentinelOption{MasterSet: masterName},
+		}, nil
+
+	case "cluster":
+		clusterAddrs := splitAddrs(os.Getenv("REDIS_CLUSTER_ADDRS"))
+		if len(clusterAddrs) == 0 {
+			return rueidis.ClientOption{}, fmt.Errorf("для режиму cluster потрібен REDIS_CLUSTER_ADDRS")
+		}
+		return rueidis.ClientOption{InitAddress: clusterAddrs}, nil
+
+	default:
+		return rueidis.ClientOption{}, fmt.Errorf("невідомий режим REDIS_MODE: %q", mode)
+	}
+}
+
+func splitAddrs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
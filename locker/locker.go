@@ -0,0 +1,79 @@
+// Package locker реалізує Redlock-подібне розподілене блокування на базі
+// Redis, щоб мутації одного й того самого ресурсу не перепліталися між
+// кількома екземплярами застосунку.
+package locker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// ErrNotAcquired повертається, коли ключ уже заблокований іншим власником.
+var ErrNotAcquired = errors.New("locker: ключ уже заблоковано")
+
+// unlockScript знімає блокування лише якщо токен збігається з тим, що
+// отримав поточний власник - так інший власник не може випадково зняти
+// чуже блокування після закінчення TTL.
+const unlockScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+else
+	return 0
+end`
+
+// Locker отримує блокування через клієнт rueidis.
+type Locker struct {
+	client rueidis.Client
+}
+
+// New створює Locker поверх уже налаштованого клієнта rueidis.
+func New(client rueidis.Client) *Locker {
+	return &Locker{client: client}
+}
+
+// Lock - утримуване блокування одного ключа, отримане через Acquire.
+type Lock struct {
+	locker *Locker
+	key    string
+	token  string
+}
+
+// Acquire намагається атомарно встановити key=token з TTL ttl (SET NX PX).
+// Повертає ErrNotAcquired, якщо ключ уже заблоковано кимось іншим.
+func (l *Locker) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("locker: не вдалося згенерувати токен: %w", err)
+	}
+
+	cmd := l.client.B().Set().Key(key).Value(token).Nx().Px(ttl).Build()
+	err = l.client.Do(ctx, cmd).Error()
+	if rueidis.IsRedisNil(err) {
+		return nil, ErrNotAcquired
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Lock{locker: l, key: key, token: token}, nil
+}
+
+// Release знімає блокування, якщо воно досі належить цьому Lock.
+func (l *Lock) Release(ctx context.Context) error {
+	cmd := l.locker.client.B().Eval().Script(unlockScript).Numkeys(1).Key(l.key).Arg(l.token).Build()
+	return l.locker.client.Do(ctx, cmd).Error()
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
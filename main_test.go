@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeStorage - реалізація Storage в пам'яті для тестів обробників, без
+// залежності від реального sqlStorage/memoryStorage.
+type fakeStorage struct {
+	tasks map[string]Task
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{tasks: make(map[string]Task)}
+}
+
+func (s *fakeStorage) List(ctx context.Context) ([]Task, error) {
+	taskList := make([]Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		taskList = append(taskList, task)
+	}
+	return taskList, nil
+}
+
+func (s *fakeStorage) Get(ctx context.Context, id string) (Task, error) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return Task{}, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (s *fakeStorage) Create(ctx context.Context, task Task) error {
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *fakeStorage) Update(ctx context.Context, task Task) error {
+	if _, ok := s.tasks[task.ID]; !ok {
+		return ErrTaskNotFound
+	}
+	s.tasks[task.ID] = task
+	return nil
+}
+
+func (s *fakeStorage) Delete(ctx context.Context, id string) error {
+	if _, ok := s.tasks[id]; !ok {
+		return ErrTaskNotFound
+	}
+	delete(s.tasks, id)
+	return nil
+}
+
+// fakeCache - реалізація TaskCache в пам'яті для тестів обробників, без
+// залежності від реального Redis.
+type fakeCache struct {
+	tasks []Task
+}
+
+func (c *fakeCache) ListTasks(ctx context.Context, opts ListTasksOptions) ([]Task, string, error) {
+	taskList := make([]Task, 0, len(c.tasks))
+	for _, task := range c.tasks {
+		if opts.Completed != nil && task.Completed != *opts.Completed {
+			continue
+		}
+		taskList = append(taskList, task)
+	}
+	return taskList, "", nil
+}
+
+func (c *fakeCache) PutTask(ctx context.Context, task Task) error {
+	for i, t := range c.tasks {
+		if t.ID == task.ID {
+			c.tasks[i] = task
+			return nil
+		}
+	}
+	c.tasks = append(c.tasks, task)
+	return nil
+}
+
+func (c *fakeCache) DeleteTask(ctx context.Context, id string) error {
+	for i, t := range c.tasks {
+		if t.ID == id {
+			c.tasks = append(c.tasks[:i], c.tasks[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (c *fakeCache) PublishEvent(ctx context.Context, event TaskEvent) error {
+	return nil
+}
+
+func (c *fakeCache) SubscribeEvents(ctx context.Context, onEvent func(TaskEvent)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCreateTaskStoresAndCaches(t *testing.T) {
+	store = newFakeStorage()
+	cache = &fakeCache{}
+
+	body := `{"id":"1","title":"Купити молоко","completed":false,"created_at":100}`
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	createTask(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("очікував %d, отримав %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	if _, err := store.Get(req.Context(), "1"); err != nil {
+		t.Fatalf("завдання не збережено в store: %v", err)
+	}
+
+	tasks, _, err := cache.ListTasks(req.Context(), ListTasksOptions{})
+	if err != nil {
+		t.Fatalf("cache.ListTasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "1" {
+		t.Fatalf("завдання не потрапило в кеш: %+v", tasks)
+	}
+}
+
+func TestGetTasksFiltersByCompleted(t *testing.T) {
+	store = newFakeStorage()
+	cache = &fakeCache{tasks: []Task{
+		{ID: "1", Title: "Перше", Completed: true, CreatedAt: 1},
+		{ID: "2", Title: "Друге", Completed: false, CreatedAt: 2},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks?completed=true", nil)
+	rec := httptest.NewRecorder()
+
+	getTasks(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("очікував %d, отримав %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var resp tasksResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("не вдалося розпарсити відповідь: %v", err)
+	}
+	if len(resp.Tasks) != 1 || resp.Tasks[0].ID != "1" {
+		t.Fatalf("фільтр completed=true повернув неправильні завдання: %+v", resp.Tasks)
+	}
+}